@@ -0,0 +1,116 @@
+// Package complete layers a Kingpin/docopt-style command completion tree
+// on top of match.Best. Commands, subcommands, and flags are registered
+// as a tree of Nodes; given a slice of argv tokens, Walk resolves the
+// tree one level at a time using match.BestFunc, so downstream CLI tools
+// don't have to reimplement the tree walk or the ambiguity rules
+// themselves. See the package example for a worked usage.
+package complete
+
+import (
+	"strings"
+
+	"github.com/as/match"
+)
+
+// Node is a single point in a command completion tree: a command,
+// subcommand, or flag. Children are the valid continuations from this
+// node, keyed by their own Name.
+type Node struct {
+	Name     string
+	IsFlag   bool
+	Children []*Node
+}
+
+// Root creates the root node of a completion tree. name is cosmetic; it
+// is never matched against since Walk starts resolving argv against
+// root's Children.
+func Root(name string) *Node {
+	return &Node{Name: name}
+}
+
+// Command registers name as a subcommand of n and returns the new node,
+// so callers can chain further Command/Flag calls to build the tree.
+func (n *Node) Command(name string) *Node {
+	c := &Node{Name: name}
+	n.Children = append(n.Children, c)
+	return c
+}
+
+// Flag registers name as a flag of n and returns the new node. Flag names
+// are stored without their leading dashes; Walk strips dashes from argv
+// tokens before matching them against flags.
+func (n *Node) Flag(name string) *Node {
+	c := &Node{Name: name, IsFlag: true}
+	n.Children = append(n.Children, c)
+	return c
+}
+
+// names returns the names of n's children whose IsFlag matches isFlag, in
+// registration order.
+func (n *Node) names(isFlag bool) []string {
+	var s []string
+	for _, c := range n.Children {
+		if c.IsFlag == isFlag {
+			s = append(s, c.Name)
+		}
+	}
+	return s
+}
+
+// child returns n's child named name with the given IsFlag, or nil if
+// there is none.
+func (n *Node) child(name string, isFlag bool) *Node {
+	for _, c := range n.Children {
+		if c.IsFlag == isFlag && c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// isFlagToken reports whether tok looks like a flag, e.g. "-v" or
+// "--verbose".
+func isFlagToken(tok string) bool {
+	return strings.HasPrefix(tok, "-")
+}
+
+// Walk resolves argv against the tree rooted at root, one token per
+// level. Ordinary tokens are resolved with match.CmpPrefix; tokens that
+// look like flags (a leading "-") have their dashes stripped and are
+// resolved against flag nodes with match.CmpLower. Walk stops and
+// returns the path resolved so far once argv is exhausted or the
+// current node has no children left to resolve against.
+//
+// If a token matches more than one continuation and none of them
+// exactly, or matches none, Walk
+// returns the *match.Error produced by match.BestFunc, whose Matches
+// field lists the ambiguous continuations at the point of failure.
+func Walk(root *Node, argv []string) ([]*Node, error) {
+	path := []*Node{root}
+	cur := root
+
+	for _, tok := range argv {
+		if len(cur.Children) == 0 {
+			break
+		}
+
+		cmp := match.CmpPrefix
+		key := tok
+		flag := isFlagToken(tok)
+		if flag {
+			cmp = match.CmpLower
+			key = strings.TrimLeft(tok, "-")
+		}
+
+		sm, err := match.BestFunc(cur.names(flag), cmp, key)
+		if err != nil {
+			return path, err
+		}
+
+		next := cur.child(sm[key].String(), flag)
+		path = append(path, next)
+		cur = next
+	}
+
+	return path, nil
+}
@@ -0,0 +1,127 @@
+package complete
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/as/match"
+)
+
+func tree() *Node {
+	root := Root("git")
+	remote := root.Command("remote")
+	remote.Command("add")
+	remote.Command("remove")
+	root.Command("rebase")
+	root.Command("status")
+	root.Flag("verbose")
+	root.Flag("version")
+	return root
+}
+
+func TestWalk(t *testing.T) {
+	cases := []struct {
+		Argv []string
+		Want []string
+	}{
+		{[]string{"stat"}, []string{"git", "status"}},
+		{[]string{"rem", "add"}, []string{"git", "remote", "add"}},
+		{[]string{"rem", "rem"}, []string{"git", "remote", "remove"}},
+		{[]string{"status", "extra", "args"}, []string{"git", "status"}},
+		{[]string{"--VERBOSE"}, []string{"git", "verbose"}},
+	}
+
+	for i, v := range cases {
+		path, err := Walk(tree(), v.Argv)
+		if err != nil {
+			t.Fatalf("TestCase %03d: unexpected error: %s", i, err)
+		}
+
+		if len(path) != len(v.Want) {
+			t.Fatalf("TestCase %03d: got %v, want %v", i, names(path), v.Want)
+		}
+
+		for j, n := range path {
+			if n.Name != v.Want[j] {
+				t.Fatalf("TestCase %03d: got %v, want %v", i, names(path), v.Want)
+			}
+		}
+	}
+}
+
+func TestWalkAmbiguous(t *testing.T) {
+	_, err := Walk(tree(), []string{"re"}) // matches both "remote" and "rebase"
+	if err == nil {
+		t.Fatal("expected error for 're', ambiguous between remote and rebase")
+	}
+
+	if e, ok := err.(*match.Error); !ok || !e.MultiMatch() {
+		t.Fatal("expected MultiMatch error")
+	}
+
+	_, err = Walk(tree(), []string{"--verb"}) // CmpLower requires an exact (case-insensitive) flag name
+	if err == nil {
+		t.Fatal("expected error: '--verb' is not an exact match for any flag")
+	}
+}
+
+func TestWalkFlagCommandCollision(t *testing.T) {
+	root := Root("git")
+	root.Command("push")
+	root.Flag("push")
+
+	path, err := Walk(root, []string{"--push"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(path) != 2 || path[1].Name != "push" || !path[1].IsFlag {
+		t.Fatalf("--push should resolve to the flag node, got %v", names(path))
+	}
+
+	path, err = Walk(root, []string{"push"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(path) != 2 || path[1].Name != "push" || path[1].IsFlag {
+		t.Fatalf("push should resolve to the command node, got %v", names(path))
+	}
+}
+
+func TestWalkNoMatch(t *testing.T) {
+	_, err := Walk(tree(), []string{"bogus"})
+	if err == nil {
+		t.Fatal("expected error for unresolvable token")
+	}
+
+	if e, ok := err.(*match.Error); !ok || e.MultiMatch() {
+		t.Fatal("expected a non-ambiguous match.Error for zero matches")
+	}
+}
+
+func names(path []*Node) []string {
+	s := make([]string, len(path))
+	for i, n := range path {
+		s[i] = n.Name
+	}
+	return s
+}
+
+// Example demonstrates completing a partial git-like command line against
+// a small completion tree.
+func Example() {
+	root := Root("git")
+	remote := root.Command("remote")
+	remote.Command("add")
+	remote.Command("remove")
+	root.Command("status")
+
+	path, _ := Walk(root, []string{"rem", "add"})
+	for _, n := range path[1:] {
+		fmt.Println(n.Name)
+	}
+	// Output:
+	// remote
+	// add
+}
@@ -0,0 +1,149 @@
+package match
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// NeedlesFuncOptions configures the worker pool that Needles and
+// NeedlesMap use to shard a haystack. Workers defaults to
+// runtime.GOMAXPROCS(0) when zero, and MinShardSize is the smallest
+// haystack length worth sharding; haystacks below it run on a single
+// goroutine so callers with small inputs don't pay for the goroutine
+// overhead.
+type NeedlesFuncOptions struct {
+	Workers      int
+	MinShardSize int
+}
+
+// defaultMinShardSize is the haystack length below which sharding costs
+// more than it saves for a typical Cmp* comparison function.
+const defaultMinShardSize = 512
+
+func (o NeedlesFuncOptions) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+func (o NeedlesFuncOptions) minShardSize() int {
+	if o.MinShardSize > 0 {
+		return o.MinShardSize
+	}
+	return defaultMinShardSize
+}
+
+// shard describes a contiguous slice of the haystack, [start, end).
+type shard struct {
+	start, end int
+}
+
+// shardHaystack splits a haystack of length n into shards per opts. It
+// always returns at least one shard; a single shard spanning the whole
+// haystack means the caller should skip the worker pool entirely.
+func shardHaystack(n int, opts NeedlesFuncOptions) []shard {
+	workers := opts.workers()
+	if workers < 1 {
+		workers = 1
+	}
+
+	if n < opts.minShardSize() || workers == 1 {
+		return []shard{{0, n}}
+	}
+
+	size := (n + workers - 1) / workers
+	if size < 1 {
+		size = 1
+	}
+
+	shards := make([]shard, 0, (n+size-1)/size)
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+		shards = append(shards, shard{start, end})
+	}
+
+	return shards
+}
+
+// Needles is the plural of Needle, finding multiple needles in the
+// haystack instead of one. Because Needles's last parameter is variadic,
+// it returns a matrix of values in the form of a Matches slice.
+//
+// By default, Needles shards the haystack across runtime.GOMAXPROCS
+// goroutines once the haystack is large enough to be worth it, merging
+// each shard's matches back in haystack order. Use NeedlesOptions to tune
+// or disable the worker pool.
+func Needles(hays []string, cmp func(string, string) bool, needles ...string) []Matches {
+	return NeedlesOptions(hays, cmp, NeedlesFuncOptions{}, needles...)
+}
+
+// NeedlesOptions is like Needles, except it lets the caller tune the
+// worker pool via opts instead of accepting the defaults.
+func NeedlesOptions(hays []string, cmp func(string, string) bool, opts NeedlesFuncOptions, needles ...string) []Matches {
+	shards := shardHaystack(len(hays), opts)
+
+	if len(shards) <= 1 {
+		ma := make([]Matches, 0, len(needles))
+		for _, n := range needles {
+			ma = append(ma, Needle(hays, cmp, n))
+		}
+		return ma
+	}
+
+	perShard := make([][]Matches, len(shards))
+	counts := make([]int64, len(needles)) // merged length per needle, updated without a mutex
+
+	var wg sync.WaitGroup
+	for i, sh := range shards {
+		wg.Add(1)
+		go func(i int, sh shard) {
+			defer wg.Done()
+
+			local := make([]Matches, len(needles))
+			for j, n := range needles {
+				for idx := sh.start; idx < sh.end; idx++ {
+					if cmp(hays[idx], n) {
+						local[j] = append(local[j], Match{idx, &hays[idx]})
+					}
+				}
+				atomic.AddInt64(&counts[j], int64(len(local[j])))
+			}
+			perShard[i] = local
+		}(i, sh)
+	}
+	wg.Wait()
+
+	ma := make([]Matches, len(needles))
+	for j := range needles {
+		ma[j] = make(Matches, 0, counts[j])
+		for i := range shards {
+			ma[j] = append(ma[j], perShard[i][j]...)
+		}
+	}
+
+	return ma
+}
+
+// NeedlesMap is like Needles, except it returns a map containing the
+// needle name as the key, and Matches as the value.
+func NeedlesMap(hays []string, cmp func(string, string) bool, needles ...string) map[string]Matches {
+	return NeedlesMapOptions(hays, cmp, NeedlesFuncOptions{}, needles...)
+}
+
+// NeedlesMapOptions is like NeedlesMap, except it lets the caller tune the
+// worker pool via opts instead of accepting the defaults.
+func NeedlesMapOptions(hays []string, cmp func(string, string) bool, opts NeedlesFuncOptions, needles ...string) map[string]Matches {
+	mslice := NeedlesOptions(hays, cmp, opts, needles...)
+	mmap := make(map[string]Matches)
+
+	for i, n := range needles {
+		mmap[n] = mslice[i]
+	}
+
+	return mmap
+}
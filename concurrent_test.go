@@ -0,0 +1,48 @@
+package match
+
+import "testing"
+
+func TestNeedlesOptionsSharded(t *testing.T) {
+	hays := make([]string, 2000)
+	for i := range hays {
+		hays[i] = "Apple"
+	}
+	hays[0] = "Orange"
+	hays[1999] = "Orange"
+
+	opts := NeedlesFuncOptions{Workers: 4, MinShardSize: 16}
+
+	ma := NeedlesOptions(hays, Cmp, opts, "Orange")
+	if len(ma) != 1 {
+		t.Fatalf("expected 1 needle result, got %d", len(ma))
+	}
+
+	if ma[0].Len() != 2 {
+		t.Fatalf("expected 2 matches, got %d", ma[0].Len())
+	}
+
+	if ma[0][0].Index != 0 || ma[0][1].Index != 1999 {
+		t.Errorf("expected matches in haystack order, got indices %d, %d", ma[0][0].Index, ma[0][1].Index)
+	}
+}
+
+func TestNeedlesOptionsSmallHaystack(t *testing.T) {
+	opts := NeedlesFuncOptions{Workers: 4, MinShardSize: 1024}
+
+	ma := NeedlesOptions(Fruit, CmpPrefix, opts, "Pea")
+	if ma[0].Len() != 2 {
+		t.Fatalf("expected 2 matches for small haystack below MinShardSize, got %d", ma[0].Len())
+	}
+}
+
+func TestNeedlesMapOptions(t *testing.T) {
+	opts := NeedlesFuncOptions{Workers: 2, MinShardSize: 1}
+
+	mm := NeedlesMapOptions(Fruit, CmpPrefix, opts, "Ban", "Oran")
+	if mm["Ban"].Len() != 1 || mm["Ban"][0].String() != "Bananna" {
+		t.Errorf("unexpected Ban matches: %v", mm["Ban"])
+	}
+	if mm["Oran"].Len() != 1 || mm["Oran"][0].String() != "Orange" {
+		t.Errorf("unexpected Oran matches: %v", mm["Oran"])
+	}
+}
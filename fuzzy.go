@@ -0,0 +1,154 @@
+package match
+
+import "sort"
+
+// Distance computes the Damerau-Levenshtein edit distance between a and b:
+// the minimum number of insertions, deletions, substitutions, and adjacent
+// transpositions required to turn a into b. It uses the standard dynamic
+// programming matrix, but keeps only three rows in memory at a time in a
+// ring buffer, since row i depends only on rows i-1 and i-2 (the latter
+// for the transposition case).
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	la, lb := len(ra), len(rb)
+
+	if la == 0 {
+		return lb
+	}
+	if lb == 0 {
+		return la
+	}
+
+	var rows [3][]int
+	for i := range rows {
+		rows[i] = make([]int, lb+1)
+	}
+	for j := 0; j <= lb; j++ {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= la; i++ {
+		im1 := rows[(i-1)%3]
+		im2 := rows[(i-2+3)%3] // dp[i-2], only read when i > 1
+		cur := rows[i%3]
+
+		cur[0] = i
+		for j := 1; j <= lb; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d := min3(im1[j]+1, cur[j-1]+1, im1[j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				if t := im2[j-2] + 1; t < d {
+					d = t
+				}
+			}
+
+			cur[j] = d
+		}
+	}
+
+	return rows[la%3][lb]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// CmpFuzzy returns a comparison function reporting whether b is within
+// maxDist edit operations of a, per Distance. The returned function
+// composes with Needle, Needles, and BestFunc wherever a stricter Cmp*
+// function would normally go.
+func CmpFuzzy(maxDist int) func(a, b string) bool {
+	return func(a, b string) bool {
+		return Distance(a, b) <= maxDist
+	}
+}
+
+// NeedleFuzzy is like Needle, but finds needle in the haystack using
+// approximate string matching instead of an exact comparator. Candidates
+// farther than maxDist are excluded, and the returned Matches are sorted
+// ascending by distance, so the closest approximate match is always first.
+func NeedleFuzzy(hays []string, maxDist int, needle string) Matches {
+	return NeedleFuzzyFunc(hays, maxDist, Distance, needle)
+}
+
+// NeedleFuzzyFunc is like NeedleFuzzy, except it scores each candidate
+// with a caller-supplied function instead of Distance. Lower scores are
+// considered closer matches, e.g. a Jaro-Winkler or trigram distance.
+func NeedleFuzzyFunc(hays []string, maxDist int, score func(a, b string) int, needle string) Matches {
+	type scored struct {
+		Match
+		dist int
+	}
+
+	sc := make([]scored, 0, len(hays))
+	for i, v := range hays {
+		if d := score(v, needle); d <= maxDist {
+			sc = append(sc, scored{Match{i, &hays[i]}, d})
+		}
+	}
+
+	sort.SliceStable(sc, func(i, j int) bool { return sc[i].dist < sc[j].dist })
+
+	ma := make(Matches, len(sc))
+	for i, v := range sc {
+		ma[i] = v.Match
+	}
+	return ma
+}
+
+// BestFuzzy maps every needle to its closest match in the haystack, ranking
+// candidates by Distance instead of requiring an exact or prefix match.
+// Candidates farther than maxDist are rejected. If the closest matches for
+// a needle tie, BestFuzzy returns an error whose MultiMatch() reports true,
+// just like Best does for ambiguous lazy matches.
+//
+// By default, BestFuzzy uses Distance to score candidates. Use
+// BestFuzzyFunc to provide your own scoring function.
+func BestFuzzy(hays []string, maxDist int, needles ...string) (map[string]Match, error) {
+	return BestFuzzyFunc(hays, maxDist, Distance, needles...)
+}
+
+// BestFuzzyFunc is like BestFuzzy, except it provides a third parameter: a
+// function scoring a haystack candidate against a needle. A lower score
+// means a closer match, letting callers substitute Jaro-Winkler, trigram,
+// or other fuzzy scoring schemes in place of Distance.
+func BestFuzzyFunc(hays []string, maxDist int, score func(a, b string) int, needles ...string) (map[string]Match, error) {
+	sm := make(map[string]Match)
+
+	for _, n := range needles {
+		ma := NeedleFuzzyFunc(hays, maxDist, score, n)
+		if ma.Len() == 0 {
+			return nil, Errorf("%s matches no fields", n)
+		}
+
+		tied := Matches{ma[0]}
+		for _, v := range ma[1:] {
+			if score(v.String(), n) != score(tied[0].String(), n) {
+				break
+			}
+			tied = append(tied, v)
+		}
+
+		if tied.Len() > 1 {
+			err := Errorf("%s matches %d fields", n, tied.Len())
+			err.Needle = n
+			err.Matches = tied
+			return nil, err
+		}
+
+		sm[n] = ma[0]
+	}
+
+	return sm, nil
+}
@@ -0,0 +1,73 @@
+package match
+
+import "testing"
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		A, B string
+		Want int
+	}{
+		{"", "", 0},
+		{"Apple", "Apple", 0},
+		{"", "Apple", 5},
+		{"Apple", "", 5},
+		{"Aple", "Apple", 1},
+		{"ab", "ab", 0},
+		{"ab", "ba", 1},
+		{"recieve", "receive", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for i, v := range cases {
+		got := Distance(v.A, v.B)
+		if got != v.Want {
+			t.Errorf("TestCase %03d: Distance(%q, %q) = %d, want %d", i, v.A, v.B, got, v.Want)
+		}
+	}
+}
+
+func TestBestFuzzy(t *testing.T) {
+	matches, err := BestFuzzy(Fruit, 2, "Aple", "Bananna")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if matches["Aple"].String() != "Apple" {
+		t.Errorf("Aple -> %s, want Apple", matches["Aple"])
+	}
+
+	if matches["Bananna"].String() != "Bananna" {
+		t.Errorf("Bananna -> %s, want Bananna", matches["Bananna"])
+	}
+}
+
+func TestBestFuzzyNoMatch(t *testing.T) {
+	_, err := BestFuzzy(Fruit, 1, "Zzzzzzzzz")
+	if err == nil {
+		t.Fatal("expected error for needle with no candidates within maxDist")
+	}
+}
+
+func TestBestFuzzyAmbiguous(t *testing.T) {
+	hays := []string{"cat", "cot", "cut"}
+
+	_, err := BestFuzzy(hays, 1, "cit")
+	if err == nil {
+		t.Fatal("expected error for tied fuzzy matches")
+	}
+
+	if e, ok := err.(*Error); !ok || !e.MultiMatch() {
+		t.Fatal("expected MultiMatch error")
+	}
+}
+
+func TestBestFuzzyFunc(t *testing.T) {
+	// A scoring function where everything is equally close makes every
+	// multi-candidate needle ambiguous.
+	zero := func(a, b string) int { return 0 }
+
+	_, err := BestFuzzyFunc(Fruit, 0, zero, "Pea")
+	if err == nil {
+		t.Fatal("expected error: all candidates score equally")
+	}
+}
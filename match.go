@@ -86,33 +86,6 @@ func Needle(hays []string, cmp func(string, string) bool, needle string) (ma Mat
 	return ma
 }
 
-// Needles is the plural of Needle, finding multiple needles in the
-// haystack instead of one. Because Needles's last parameter is variadic,
-// it returns a matrix of values in the form of a Matches slice
-func Needles(hays []string, cmp func(string, string) bool, needles ...string) []Matches {
-	ma := make([]Matches, 0, len(needles))
-
-	for _, n := range needles {
-		m := Needle(hays, cmp, n)
-		ma = append(ma, m)
-	}
-
-	return ma
-}
-
-// NeedlesMap is like Needles, except it returns a map containing the needle name
-// as the key, and Matches as the value
-func NeedlesMap(hays []string, cmp func(string, string) bool, needles ...string) map[string]Matches {
-	mslice := Needles(hays, cmp, needles...)
-	mmap := make(map[string]Matches)
-
-	for i, n := range needles {
-		mmap[n] = mslice[i]
-	}
-
-	return mmap
-}
-
 // filter filters a map of Matches by looking at every Matches value
 // in the map and moving any exact matches to the front of each Matches
 // slice. If a Matches value contains more than one match, but niether
@@ -0,0 +1,97 @@
+package match
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+)
+
+// CmpRegex returns a comparison function reporting whether a matches the
+// given regular expression pattern. The pattern is compiled once, when
+// CmpRegex is called, rather than on every comparison, so the returned
+// function is cheap to reuse across a large haystack.
+func CmpRegex(pattern string) func(a, b string) bool {
+	re := regexp.MustCompile(pattern)
+	return func(a, b string) bool {
+		return re.MatchString(a)
+	}
+}
+
+// NeedleReader is like Needle, but scans a line-oriented io.Reader instead
+// of requiring a fully materialized haystack. This lets callers search
+// large files or pipes without loading them into memory first. Match.Index
+// holds the 0-based line number on which the needle was found, and
+// Match.data points to the captured line.
+func NeedleReader(r io.Reader, cmp func(string, string) bool, needle string) (Matches, error) {
+	var ma Matches
+
+	sc := bufio.NewScanner(r)
+	for i := 0; sc.Scan(); i++ {
+		line := sc.Text()
+		if cmp(line, needle) {
+			ma = append(ma, Match{i, &line})
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return ma, nil
+}
+
+// BestReader is like BestFunc, but scans a line-oriented io.Reader instead
+// of requiring a fully materialized haystack. Because a reader can only be
+// consumed once, BestReader buffers only the lines that match at least one
+// needle, then resolves ambiguity and exact matches exactly as Best does.
+func BestReader(r io.Reader, cmp func(string, string) bool, needles ...string) (map[string]Match, error) {
+	type row struct {
+		index   int
+		text    string
+		needles []string
+	}
+
+	var rows []row
+
+	sc := bufio.NewScanner(r)
+	for i := 0; sc.Scan(); i++ {
+		s := sc.Text()
+
+		var hit []string
+		for _, n := range needles {
+			if cmp(s, n) {
+				hit = append(hit, n)
+			}
+		}
+
+		if len(hit) > 0 {
+			rows = append(rows, row{i, s, hit})
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	mm := make(map[string]Matches)
+	for _, n := range needles {
+		mm[n] = nil
+	}
+
+	for i := range rows {
+		for _, n := range rows[i].needles {
+			mm[n] = append(mm[n], Match{rows[i].index, &rows[i].text})
+		}
+	}
+
+	if err := filter(mm); err != nil {
+		return nil, err
+	}
+
+	sm := make(map[string]Match)
+	for k := range mm {
+		sm[k] = mm[k][0]
+	}
+
+	return sm, nil
+}
@@ -0,0 +1,78 @@
+package match
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCmpRegex(t *testing.T) {
+	cmp := CmpRegex(`^Pea`)
+
+	if !cmp("Peach", "") {
+		t.Error("expected Peach to match ^Pea")
+	}
+
+	if cmp("Apple", "") {
+		t.Error("did not expect Apple to match ^Pea")
+	}
+}
+
+func TestNeedleReader(t *testing.T) {
+	r := strings.NewReader(strings.Join(Fruit, "\n"))
+
+	ma, err := NeedleReader(r, CmpPrefix, "Pea")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if ma.Len() != 2 {
+		t.Fatalf("expected 2 matches, got %d", ma.Len())
+	}
+
+	if ma[0].Index != 4 || ma[0].String() != "Pear" {
+		t.Errorf("unexpected first match: %#v", ma[0])
+	}
+
+	if ma[1].Index != 5 || ma[1].String() != "Peach" {
+		t.Errorf("unexpected second match: %#v", ma[1])
+	}
+}
+
+func TestBestReader(t *testing.T) {
+	r := strings.NewReader(strings.Join(Fruit, "\n"))
+
+	sm, err := BestReader(r, CmpPrefix, "Ban", "Oran")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if sm["Ban"].String() != "Bananna" {
+		t.Errorf("Ban -> %s, want Bananna", sm["Ban"])
+	}
+
+	if sm["Oran"].String() != "Orange" {
+		t.Errorf("Oran -> %s, want Orange", sm["Oran"])
+	}
+}
+
+func TestBestReaderAmbiguous(t *testing.T) {
+	r := strings.NewReader(strings.Join(Fruit, "\n"))
+
+	_, err := BestReader(r, CmpPrefix, "Pea")
+	if err == nil {
+		t.Fatal("expected error for ambiguous needle")
+	}
+
+	if e, ok := err.(*Error); !ok || !e.MultiMatch() {
+		t.Fatal("expected MultiMatch error")
+	}
+}
+
+func TestBestReaderNoMatch(t *testing.T) {
+	r := strings.NewReader(strings.Join(Fruit, "\n"))
+
+	_, err := BestReader(r, CmpPrefix, "Zzz")
+	if err == nil {
+		t.Fatal("expected error for needle with no match")
+	}
+}
@@ -0,0 +1,77 @@
+package match
+
+// Scorer pairs a lazy comparison function with a weight. BestScored sums
+// the weights of every Scorer whose Fn matches a given haystack
+// candidate against the needle, then picks the candidate with the
+// highest total.
+type Scorer struct {
+	Fn     func(string, string) bool
+	Weight float64
+}
+
+// BestScored maps every needle to its highest-scoring match in the
+// haystack. Every haystack candidate accumulates a score across all
+// scorers whose Fn returns true for the needle, and the candidate with
+// the highest total score wins, even when several lazy comparators
+// match it at once. Ambiguity is only reported when the top score ties
+// between two or more candidates.
+//
+// This lets callers combine, say, CmpPrefix (weight 1.0) with an exact
+// CmpLower match (weight 5.0) and a user-supplied fuzzy matcher (weight
+// 0.2) in a single call, rather than running BestFunc multiple times and
+// reconciling the results by hand.
+func BestScored(hays []string, scorers []Scorer, needles ...string) (map[string]Match, error) {
+	sm := make(map[string]Match)
+
+	for _, n := range needles {
+		best, err := bestScored(hays, scorers, n)
+		if err != nil {
+			return nil, err
+		}
+		sm[n] = best
+	}
+
+	return sm, nil
+}
+
+// bestScored finds the highest-scoring match for a single needle,
+// mirroring the ambiguity rules of filter: zero candidates is an error,
+// and a tie at the top score is an error with Matches populated.
+func bestScored(hays []string, scorers []Scorer, needle string) (Match, error) {
+	var top float64
+	var tied Matches
+
+	for i, v := range hays {
+		var score float64
+		for _, s := range scorers {
+			if s.Fn(v, needle) {
+				score += s.Weight
+			}
+		}
+
+		if score <= 0 {
+			continue
+		}
+
+		switch {
+		case score > top:
+			top = score
+			tied = Matches{{i, &hays[i]}}
+		case score == top:
+			tied = append(tied, Match{i, &hays[i]})
+		}
+	}
+
+	if tied.Len() == 0 {
+		return Match{}, Errorf("%s matches no fields", needle)
+	}
+
+	if tied.Len() > 1 {
+		err := Errorf("%s matches %d fields", needle, tied.Len())
+		err.Needle = needle
+		err.Matches = tied
+		return Match{}, err
+	}
+
+	return tied[0], nil
+}
@@ -0,0 +1,63 @@
+package match
+
+import "testing"
+
+func TestBestScored(t *testing.T) {
+	scorers := []Scorer{
+		{Fn: CmpPrefix, Weight: 1.0},
+		{Fn: CmpLower, Weight: 5.0},
+	}
+
+	sm, err := BestScored(Fruit, scorers, "Apple")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if sm["Apple"].String() != "Apple" {
+		t.Errorf("Apple -> %s, want Apple (exact match should outweigh prefix matches alone)", sm["Apple"])
+	}
+}
+
+func TestBestScoredAmbiguousTie(t *testing.T) {
+	scorers := []Scorer{
+		{Fn: CmpPrefix, Weight: 1.0},
+	}
+
+	_, err := BestScored(Fruit, scorers, "Pea")
+	if err == nil {
+		t.Fatal("expected error: Pea ties between Pear and Peach under a single scorer")
+	}
+
+	if e, ok := err.(*Error); !ok || !e.MultiMatch() {
+		t.Fatal("expected MultiMatch error")
+	}
+}
+
+func TestBestScoredNoMatch(t *testing.T) {
+	scorers := []Scorer{
+		{Fn: Cmp, Weight: 1.0},
+	}
+
+	_, err := BestScored(Fruit, scorers, "Zzz")
+	if err == nil {
+		t.Fatal("expected error for needle with no scoring matches")
+	}
+}
+
+func TestBestScoredWeightBreaksTie(t *testing.T) {
+	// CmpPrefix matches "Pea" against Pear and Peach equally, but the
+	// second scorer matches only Pear, so its weight breaks the tie.
+	scorers := []Scorer{
+		{Fn: CmpPrefix, Weight: 1.0},
+		{Fn: func(a, b string) bool { return a == "Pear" && b == "Pea" }, Weight: 10.0},
+	}
+
+	sm, err := BestScored(Fruit, scorers, "Pea")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if sm["Pea"].String() != "Pear" {
+		t.Errorf("Pea -> %s, want Pear", sm["Pea"])
+	}
+}